@@ -0,0 +1,92 @@
+package ftp
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// dateLayouts is the curated, ordered list of Go reference layouts
+// parseFlexibleDate tries against a LIST line's date/time field once the
+// format-specific strict parsers in this package have given up. Order
+// matters: the first layout that parses the string wins, so prefer more
+// specific layouts first.
+var dateLayouts = []string{
+	"_2 Jan 2006 15:04",
+	"_2 Jan 2006",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05Z07:00",
+	"2006-01-02T15:04:05.999999999Z07:00",
+	"02/01/06 15:04:05",
+	"01/02/2006 15:04:05",
+	"02-Jan-2006 15:04",
+	"Jan _2 15:04:05 2006",
+}
+
+var dateLayoutsMu sync.RWMutex
+
+// RegisterDateLayout adds layout to the set of layouts parseFlexibleDate
+// tries. Layouts are tried in registration order after the built-in ones,
+// so the built-ins still take precedence over a newly registered layout
+// that happens to also match.
+func RegisterDateLayout(layout string) {
+	dateLayoutsMu.Lock()
+	defer dateLayoutsMu.Unlock()
+	dateLayouts = append(dateLayouts, layout)
+}
+
+// parseFlexibleDate tries each registered layout against str in loc,
+// returning the first one that parses successfully. A layout that carries
+// no year directive (as a custom layout registered with RegisterDateLayout
+// might, e.g. an `ls`-style "Jan _2 15:04:05") parses to year 0; for those,
+// and only those, now's year is substituted in and, like setTime, a result
+// that then lands more than six months in the future is assumed to have
+// rolled over a year boundary and is pulled back a year. Every built-in
+// layout carries an explicit year, so none of them are subject to this
+// rollback.
+func parseFlexibleDate(str string, now time.Time, loc *time.Location) (time.Time, error) {
+	dateLayoutsMu.RLock()
+	layouts := make([]string, len(dateLayouts))
+	copy(layouts, dateLayouts)
+	dateLayoutsMu.RUnlock()
+
+	for _, layout := range layouts {
+		t, err := time.ParseInLocation(layout, str, loc)
+		if err != nil {
+			continue
+		}
+
+		if t.Year() == 0 {
+			t = time.Date(now.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+			if !t.Before(now.AddDate(0, 6, 0)) {
+				t = t.AddDate(-1, 0, 0)
+			}
+		}
+		return t, nil
+	}
+	return time.Time{}, errUnsupportedListDate
+}
+
+// parseFlexibleDatePrefix tries to parse a timestamp out of the leading
+// whitespace-delimited tokens of line, trying 1 up to 4 tokens joined by a
+// single space against parseFlexibleDate. It returns the unconsumed
+// remainder of line alongside the parsed time.
+func parseFlexibleDatePrefix(line string, now time.Time, loc *time.Location) (rest string, t time.Time, err error) {
+	fields := strings.Fields(line)
+
+	for n := 1; n <= 4 && n <= len(fields); n++ {
+		candidate := strings.Join(fields[:n], " ")
+		t, err = parseFlexibleDate(candidate, now, loc)
+		if err != nil {
+			continue
+		}
+
+		rest = line
+		for i := 0; i < n; i++ {
+			j := strings.Index(rest, fields[i])
+			rest = rest[j+len(fields[i]):]
+		}
+		return rest, t, nil
+	}
+	return "", time.Time{}, errUnsupportedListDate
+}
@@ -0,0 +1,107 @@
+package ftp
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+// MLSTPerm represents the RFC 3659 "perm" fact: the set of operations the
+// current user is permitted to perform on the listed object.
+type MLSTPerm uint16
+
+const (
+	PermAppend   MLSTPerm = 1 << iota // a: file may be appended to
+	PermCreate                        // c: a file may be created in this directory
+	PermDelete                        // d: object may be deleted
+	PermEnter                         // e: directory may be entered (CWD)
+	PermRename                        // f: object may be renamed
+	PermList                          // l: directory may be listed
+	PermMkdir                         // m: a subdirectory may be created here
+	PermPurge                         // p: objects within this directory may be deleted
+	PermRetrieve                      // r: file may be retrieved (RETR)
+	PermStore                         // w: file may be stored (STOR)
+)
+
+// Has reports whether p includes every bit set in want.
+func (p MLSTPerm) Has(want MLSTPerm) bool {
+	return p&want == want
+}
+
+func parseMLSTPerm(value string) MLSTPerm {
+	var p MLSTPerm
+	for _, c := range value {
+		switch c {
+		case 'a':
+			p |= PermAppend
+		case 'c':
+			p |= PermCreate
+		case 'd':
+			p |= PermDelete
+		case 'e':
+			p |= PermEnter
+		case 'f':
+			p |= PermRename
+		case 'l':
+			p |= PermList
+		case 'm':
+			p |= PermMkdir
+		case 'p':
+			p |= PermPurge
+		case 'r':
+			p |= PermRetrieve
+		case 'w':
+			p |= PermStore
+		}
+	}
+	return p
+}
+
+// ModifyTime returns the value of the RFC 3659 "modify" fact. It is the same
+// value as Entry.Time.
+func (e *Entry) ModifyTime() time.Time {
+	return e.Time
+}
+
+// CreateTime returns the value of the RFC 3659 "create" fact and whether the
+// server sent one. It's parsed in the same location as ModifyTime/Entry.Time
+// (e.factLoc, recorded by parseNextRFC3659ListLine), falling back to UTC for
+// an Entry built without going through that parser.
+func (e *Entry) CreateTime() (time.Time, bool) {
+	value, ok := e.Facts["create"]
+	if !ok {
+		return time.Time{}, false
+	}
+	loc := e.factLoc
+	if loc == nil {
+		loc = time.UTC
+	}
+	t, err := time.ParseInLocation("20060102150405", value, loc)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// UnixMode returns the value of the "UNIX.mode" fact parsed as an octal file
+// mode, and whether the server sent one.
+func (e *Entry) UnixMode() (os.FileMode, bool) {
+	value, ok := e.Facts["unix.mode"]
+	if !ok {
+		return 0, false
+	}
+	mode, err := strconv.ParseUint(value, 8, 32)
+	if err != nil {
+		return 0, false
+	}
+	return os.FileMode(mode), true
+}
+
+// Perm returns the value of the "perm" fact and whether the server sent one.
+func (e *Entry) Perm() (MLSTPerm, bool) {
+	value, ok := e.Facts["perm"]
+	if !ok {
+		return 0, false
+	}
+	return parseMLSTPerm(value), true
+}
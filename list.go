@@ -0,0 +1,63 @@
+package ftp
+
+import (
+	"strings"
+	"time"
+)
+
+// List issues MLSD for path and, if the server doesn't support it, falls
+// back to LIST. MLSD lines are parsed with the same RFC 3659 parser MLST
+// uses; LIST lines go through c.parseListLine, so a WithListParsers
+// override set on c applies here too, instead of only affecting callers
+// that parse LIST output themselves.
+func (c *ServerConn) List(path string) ([]*Entry, error) {
+	if entries, err := c.mlsd(path); err == nil {
+		return entries, nil
+	}
+
+	_, msg, err := c.cmd(StatusRequestedFileActionOK, "LIST %s", path)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var entries []*Entry
+	for _, line := range strings.Split(msg, "\n") {
+		line = strings.TrimRight(line, "\r")
+		line = strings.TrimPrefix(line, " ")
+		if line == "" {
+			continue
+		}
+		entry, err := c.parseListLine(line, now)
+		if err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// mlsd issues MLSD for path and parses every returned line with the same
+// RFC 3659 fact parser MLST uses. Its error is only used by List to decide
+// whether to fall back to LIST, not surfaced to callers directly.
+func (c *ServerConn) mlsd(path string) ([]*Entry, error) {
+	_, msg, err := c.cmd(StatusRequestedFileActionOK, "MLSD %s", path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*Entry
+	for _, line := range strings.Split(msg, "\n") {
+		line = strings.TrimRight(line, "\r")
+		line = strings.TrimPrefix(line, " ")
+		if !strings.Contains(line, "=") {
+			continue
+		}
+		entry, err := parseNextRFC3659ListLine(line, c.options.location, &Entry{})
+		if err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
@@ -0,0 +1,92 @@
+package ftp
+
+import (
+	"sync"
+	"time"
+)
+
+var (
+	listParsersMu         sync.RWMutex
+	customListParsers     = map[string]ParseFunc{}
+	customListParserOrder []string
+)
+
+// RegisterListParser registers f under name so that parseListLine also
+// tries it, in addition to the built-in parsers, when decoding LIST output.
+// This lets callers support FTP dialects this package doesn't recognize
+// out of the box (Novell NetWare, MultiNet/VMS, mainframe MVS PDS, ...)
+// without forking the module. Registering under a name that is already
+// registered replaces the previous parser in place, without changing its
+// position in the iteration order.
+func RegisterListParser(name string, f ParseFunc) {
+	listParsersMu.Lock()
+	defer listParsersMu.Unlock()
+
+	if _, ok := customListParsers[name]; !ok {
+		customListParserOrder = append(customListParserOrder, name)
+	}
+	customListParsers[name] = f
+}
+
+// UnregisterListParser removes the parser previously registered under name.
+// It is a no-op if name was never registered.
+func UnregisterListParser(name string) {
+	listParsersMu.Lock()
+	defer listParsersMu.Unlock()
+
+	if _, ok := customListParsers[name]; !ok {
+		return
+	}
+	delete(customListParsers, name)
+	for i, n := range customListParserOrder {
+		if n == name {
+			customListParserOrder = append(customListParserOrder[:i], customListParserOrder[i+1:]...)
+			break
+		}
+	}
+}
+
+// ListParsers returns the parsers parseListLine tries, in order: the
+// built-in parsers followed by any parsers registered with
+// RegisterListParser, in the order they were registered.
+func ListParsers() []ParseFunc {
+	listParsersMu.RLock()
+	defer listParsersMu.RUnlock()
+
+	parsers := make([]ParseFunc, 0, len(listLineParsers)+len(customListParserOrder))
+	parsers = append(parsers, listLineParsers...)
+	for _, name := range customListParserOrder {
+		parsers = append(parsers, customListParsers[name])
+	}
+	return parsers
+}
+
+// WithListParsers overrides the parsers a ServerConn uses to decode LIST
+// output, in iteration order. This is useful for a single server that
+// mis-detects against the globally registered parsers without affecting
+// any other connection. Passing no parsers restores the default of using
+// ListParsers(). List consults c.listParsers() for every line it parses, so
+// the override takes effect for that connection alone.
+func WithListParsers(parsers ...ParseFunc) DialOption {
+	return DialOption{func(do *dialOptions) {
+		do.listParsers = parsers
+	}}
+}
+
+// listParsers returns the parsers c should try when decoding a LIST line:
+// the parsers passed to WithListParsers when c was dialed, or the globally
+// registered ListParsers() if none were configured.
+func (c *ServerConn) listParsers() []ParseFunc {
+	if len(c.options.listParsers) > 0 {
+		return c.options.listParsers
+	}
+	return ListParsers()
+}
+
+// parseListLine parses a single line of LIST output for c, using c's
+// configured parsers in place of the global registry. List calls this for
+// every line of a LIST-based directory listing, so a WithListParsers
+// override takes effect for that connection alone.
+func (c *ServerConn) parseListLine(line string, now time.Time) (*Entry, error) {
+	return parseListLineWith(line, now, c.options.location, c.listParsers())
+}
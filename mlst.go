@@ -0,0 +1,26 @@
+package ftp
+
+import "strings"
+
+// MLST issues the MLST command for path and returns the single Entry the
+// server describes, using the same fact grammar as MLSD. Not every server
+// supports MLST; callers should check the server's FEAT response before
+// relying on it.
+func (c *ServerConn) MLST(path string) (*Entry, error) {
+	_, msg, err := c.cmd(StatusRequestedFileActionOK, "MLST %s", path)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, line := range strings.Split(msg, "\n") {
+		line = strings.TrimRight(line, "\r")
+		line = strings.TrimPrefix(line, " ")
+		if !strings.Contains(line, "=") {
+			// Skip the "250-Listing ..." / "250 End" framing lines.
+			continue
+		}
+
+		return parseNextRFC3659ListLine(line, c.options.location, &Entry{})
+	}
+	return nil, errUnsupportedListLine
+}
@@ -12,13 +12,23 @@ var errUnsupportedListLine = errors.New("unsupported LIST line")
 var errUnsupportedListDate = errors.New("unsupported LIST date")
 var errUnknownListEntryType = errors.New("unknown entry type")
 
-type parseFunc func(string, time.Time, *time.Location) (*Entry, error)
-
-var listLineParsers = []parseFunc{
+// ParseFunc parses a single line of LIST output into an Entry. now is used
+// to resolve ambiguous dates and loc is the timezone assumed for timestamps
+// that don't carry one. Implementations must return errUnsupportedListLine
+// when the line doesn't match their format so that parseListLine moves on
+// to the next parser.
+type ParseFunc func(string, time.Time, *time.Location) (*Entry, error)
+
+var listLineParsers = []ParseFunc{
 	parseRFC3659ListLine,
 	parseLsListLine,
 	parseDirListLine,
 	parseHostedFTPLine,
+	// parseQSYSListLine must run before parseIbmListLine: both recognize the
+	// same owner/size/date/time/type/name columns, but parseQSYSListLine is
+	// the stricter of the two (it only matches the *FILE/*MBR/*LIB object
+	// types) and is the one that maps *LIB to EntryTypeFolder.
+	parseQSYSListLine,
 	parseIbmListLine,
 }
 
@@ -34,6 +44,10 @@ func parseRFC3659ListLine(line string, _ time.Time, loc *time.Location) (*Entry,
 	return parseNextRFC3659ListLine(line, loc, &Entry{})
 }
 
+// mlstSlinkPrefix is the RFC 3659 "type" fact value UNIX servers use to
+// describe a symlink, e.g. "type=OS.unix=slink:usr/bin".
+const mlstSlinkPrefix = "os.unix=slink:"
+
 func parseNextRFC3659ListLine(line string, loc *time.Location, e *Entry) (*Entry, error) {
 	iSemicolon := strings.Index(line, ";")
 	iWhitespace := strings.Index(line, " ")
@@ -41,6 +55,11 @@ func parseNextRFC3659ListLine(line string, loc *time.Location, e *Entry) (*Entry
 	if iSemicolon < 0 || iSemicolon > iWhitespace {
 		return nil, errUnsupportedListLine
 	}
+	if iWhitespace < 1 || line[iWhitespace-1] != ';' {
+		// The facts section must end in "; " before the name; anything else
+		// means the line isn't in the format we think it is.
+		return nil, errUnsupportedListLine
+	}
 
 	name := line[iWhitespace+1:]
 	if e.Name == "" {
@@ -50,6 +69,14 @@ func parseNextRFC3659ListLine(line string, loc *time.Location, e *Entry) (*Entry
 		return nil, errUnsupportedListLine
 	}
 
+	// Recorded so CreateTime can parse the "create" fact in the same
+	// location as "modify", instead of assuming UTC.
+	e.factLoc = loc
+
+	if e.Facts == nil {
+		e.Facts = make(map[string]string)
+	}
+
 	for _, field := range strings.Split(line[:iWhitespace-1], ";") {
 		i := strings.Index(field, "=")
 		if i < 1 {
@@ -58,6 +85,7 @@ func parseNextRFC3659ListLine(line string, loc *time.Location, e *Entry) (*Entry
 
 		key := strings.ToLower(field[:i])
 		value := field[i+1:]
+		e.Facts[key] = value
 
 		switch key {
 		case "modify":
@@ -67,11 +95,14 @@ func parseNextRFC3659ListLine(line string, loc *time.Location, e *Entry) (*Entry
 				return nil, err
 			}
 		case "type":
-			switch value {
-			case "dir", "cdir", "pdir":
+			switch {
+			case value == "dir", value == "cdir", value == "pdir":
 				e.Type = EntryTypeFolder
-			case "file":
+			case value == "file":
 				e.Type = EntryTypeFile
+			case strings.HasPrefix(strings.ToLower(value), mlstSlinkPrefix):
+				e.Type = EntryTypeLink
+				e.Target = value[len(mlstSlinkPrefix):]
 			}
 		case "size":
 			if err := e.setSize(value); err != nil {
@@ -182,8 +213,14 @@ func parseDirListLine(line string, now time.Time, loc *time.Location) (*Entry, e
 		}
 	}
 	if err != nil {
-		// None of the time formats worked.
-		return nil, errUnsupportedListLine
+		// None of the fixed-width DIR formats worked; fall back to a
+		// broader set of layouts before giving up entirely.
+		rest, t, ferr := parseFlexibleDatePrefix(line, now, loc)
+		if ferr != nil {
+			return nil, errUnsupportedListLine
+		}
+		e.Time = t
+		line = rest
 	}
 
 	line = strings.TrimLeft(line, " ")
@@ -257,12 +294,16 @@ func parseIbmListLine(line string, now time.Time, loc *time.Location) (*Entry, e
 
 	// Next fields are date and time
 	dateStr := scanner.Next()
-	if dateStr == "" || len(dateStr) != 8 { // YY/MM/DD format
+	if dateStr == "" {
+		return nil, errUnsupportedListLine
+	}
+	// A non-numeric size column means the line belongs to another dialect.
+	if _, err := strconv.ParseUint(sizeStr, 10, 64); err != nil {
 		return nil, errUnsupportedListLine
 	}
 
 	timeStr := scanner.Next()
-	if timeStr == "" || len(timeStr) != 8 { // HH:MM:SS format
+	if timeStr == "" {
 		return nil, errUnsupportedListLine
 	}
 
@@ -284,66 +325,165 @@ func parseIbmListLine(line string, now time.Time, loc *time.Location) (*Entry, e
 		return nil, err
 	}
 
-	// 13/05/25 is meant to be year=2025, month=05, day=13
-	day, err := strconv.Atoi(dateStr[0:2])
+	timestamp, err := parseIbmDateTime(dateStr, timeStr, now, loc)
 	if err != nil {
 		return nil, err
 	}
 
+	// Determine entry type
+	entryType := EntryTypeFile
+	if fileType == "*DIR" {
+		entryType = EntryTypeFolder
+	}
+
+	// Get the entry name from the path
+	name := path
+	if entryType == EntryTypeFolder && strings.HasSuffix(name, "/") {
+		// Remove trailing slash for directory names
+		name = name[:len(name)-1]
+	}
+
+	return &Entry{
+		Name:          name,
+		Size:          size,
+		Time:          timestamp,
+		Type:          entryType,
+		IBMObjectType: fileType,
+	}, nil
+}
+
+// parseIbmDateTime parses the "DD/MM/YY HH:MM:SS" timestamp shared by
+// parseIbmListLine and parseQSYSListLine. The two-digit year is pivoted
+// around now with pivotIbmYear, so it keeps working once the two-digit
+// year rolls over or for archives that predate 2000. Anything that doesn't fit the fixed-width rendering falls
+// back to parseFlexibleDate.
+func parseIbmDateTime(dateStr, timeStr string, now time.Time, loc *time.Location) (time.Time, error) {
+	if len(dateStr) != 8 || len(timeStr) != 8 {
+		return parseFlexibleDate(dateStr+" "+timeStr, now, loc)
+	}
+
+	// 13/05/25 is meant to be day=13, month=05, year=2025.
+	day, err := strconv.Atoi(dateStr[0:2])
+	if err != nil {
+		return time.Time{}, err
+	}
+
 	month, err := strconv.Atoi(dateStr[3:5])
 	if err != nil {
-		return nil, err
+		return time.Time{}, err
 	}
 
-	year, err := strconv.Atoi(dateStr[6:8])
+	twoDigitYear, err := strconv.Atoi(dateStr[6:8])
 	if err != nil {
-		return nil, err
+		return time.Time{}, err
 	}
-	// Assuming YY is actually the full year 2025
-	year = 2000 + year
+	year := pivotIbmYear(twoDigitYear, now)
 
 	hour, err := strconv.Atoi(timeStr[0:2])
 	if err != nil {
-		return nil, err
+		return time.Time{}, err
 	}
 
 	min, err := strconv.Atoi(timeStr[3:5])
 	if err != nil {
-		return nil, err
+		return time.Time{}, err
 	}
 
 	sec, err := strconv.Atoi(timeStr[6:8])
 	if err != nil {
-		return nil, err
+		return time.Time{}, err
 	}
 
-	timestamp := time.Date(year, time.Month(month), day, hour, min, sec, 0, loc)
+	return time.Date(year, time.Month(month), day, hour, min, sec, 0, loc), nil
+}
 
-	// Determine entry type
-	entryType := EntryTypeFile
-	if fileType == "*DIR" {
-		entryType = EntryTypeFolder
+// pivotIbmYear resolves a two-digit year the way IBM i FTP servers emit it
+// (no indication of the century) into the four-digit year within 50 years
+// of now that ends in those two digits.
+func pivotIbmYear(twoDigit int, now time.Time) int {
+	year := (now.Year()/100)*100 + twoDigit
+	switch {
+	case year-now.Year() > 50:
+		year -= 100
+	case now.Year()-year > 50:
+		year += 100
+	}
+	return year
+}
+
+// parseQSYSListLine parses the QSYS.LIB "library/file(member)" style of
+// listing iSeries FTP servers emit when SITE NAMEFMT 0 is in effect, e.g.:
+//
+//	QGPL             8192 15/07/23 10:15:30 *LIB       QGPL
+//	MYLIB             512 15/07/23 10:15:30 *FILE      MYFILE
+//	MYLIB             256 15/07/23 10:15:31 *MBR       MYFILE(MBR1)
+func parseQSYSListLine(line string, now time.Time, loc *time.Location) (*Entry, error) {
+	scanner := newScanner(line)
+
+	// First field is the library.
+	if scanner.Next() == "" {
+		return nil, errUnsupportedListLine
 	}
 
-	// Get the entry name from the path
-	name := path
-	if entryType == EntryTypeFolder && strings.HasSuffix(name, "/") {
-		// Remove trailing slash for directory names
-		name = name[:len(name)-1]
+	sizeStr := scanner.Next()
+	if sizeStr == "" {
+		return nil, errUnsupportedListLine
+	}
+
+	dateStr := scanner.Next()
+	timeStr := scanner.Next()
+	if dateStr == "" || timeStr == "" {
+		return nil, errUnsupportedListLine
+	}
+
+	objType := scanner.Next()
+	switch objType {
+	case "*FILE", "*MBR", "*LIB":
+	default:
+		return nil, errUnsupportedListLine
+	}
+
+	name := strings.TrimLeft(scanner.Remaining(), " ")
+	if name == "" {
+		return nil, errUnsupportedListLine
+	}
+
+	size, err := strconv.ParseUint(sizeStr, 10, 64)
+	if err != nil {
+		return nil, errUnsupportedListLine
+	}
+
+	timestamp, err := parseIbmDateTime(dateStr, timeStr, now, loc)
+	if err != nil {
+		return nil, errUnsupportedListLine
+	}
+
+	entryType := EntryTypeFile
+	if objType == "*LIB" {
+		entryType = EntryTypeFolder
 	}
 
 	return &Entry{
-		Name: name,
-		Size: size,
-		Time: timestamp,
-		Type: entryType,
+		Name:          name,
+		Size:          size,
+		Time:          timestamp,
+		Type:          entryType,
+		IBMObjectType: objType,
 	}, nil
 }
 
 // parseListLine parses the various non-standard format returned by the LIST
-// FTP command.
+// FTP command, trying ListParsers() in order and stopping at the first
+// parser that recognizes the line.
 func parseListLine(line string, now time.Time, loc *time.Location) (*Entry, error) {
-	for _, f := range listLineParsers {
+	return parseListLineWith(line, now, loc, ListParsers())
+}
+
+// parseListLineWith is like parseListLine but tries parsers instead of the
+// globally registered list, so a ServerConn configured with WithListParsers
+// can override parsing on a per-connection basis.
+func parseListLineWith(line string, now time.Time, loc *time.Location, parsers []ParseFunc) (*Entry, error) {
+	for _, f := range parsers {
 		e, err := f(line, now, loc)
 		if err != errUnsupportedListLine {
 			return e, err
@@ -381,10 +521,19 @@ func (e *Entry) setTime(fields []string, now time.Time, loc *time.Location) (err
 
 	} else { // only the date
 		if len(fields[2]) != 4 {
-			return errUnsupportedListDate
+			err = errUnsupportedListDate
+		} else {
+			timeStr := fmt.Sprintf("%s %s %s 00:00", fields[1], fields[0], fields[2])
+			e.Time, err = time.ParseInLocation("_2 Jan 2006 15:04", timeStr, loc)
+		}
+	}
+
+	if err != nil {
+		// Last resort: try a broader set of layouts before giving up.
+		if t, ferr := parseFlexibleDate(strings.Join(fields, " "), now, loc); ferr == nil {
+			e.Time = t
+			err = nil
 		}
-		timeStr := fmt.Sprintf("%s %s %s 00:00", fields[1], fields[0], fields[2])
-		e.Time, err = time.ParseInLocation("_2 Jan 2006 15:04", timeStr, loc)
 	}
 	return
 }
@@ -1,6 +1,7 @@
 package ftp
 
 import (
+	"os"
 	"strings"
 	"testing"
 	"time"
@@ -242,6 +243,231 @@ func TestParseIbmListLine(t *testing.T) {
 	}
 }
 
+func TestPivotIbmYear(t *testing.T) {
+	tests := []struct {
+		twoDigit int
+		now      time.Time
+		expected int
+	}{
+		{25, newTime(2023, time.January, 1), 2025},
+		{99, newTime(2005, time.January, 1), 1999}, // pre-2000 archive
+		{1, newTime(2099, time.January, 1), 2101},  // rollover into a new century
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, pivotIbmYear(test.twoDigit, test.now))
+	}
+}
+
+func TestParseQSYSListLine(t *testing.T) {
+	now := newTime(2023, time.January, 1)
+
+	tests := []struct {
+		line     string
+		expected Entry
+	}{
+		{
+			"QGPL             8192 15/07/23 10:15:30 *LIB       QGPL",
+			Entry{Name: "QGPL", Size: 8192, Time: newTime(2023, time.July, 15, 10, 15, 30), Type: EntryTypeFolder, IBMObjectType: "*LIB"},
+		},
+		{
+			"MYLIB             512 15/07/23 10:15:30 *FILE      MYFILE",
+			Entry{Name: "MYFILE", Size: 512, Time: newTime(2023, time.July, 15, 10, 15, 30), Type: EntryTypeFile, IBMObjectType: "*FILE"},
+		},
+		{
+			"MYLIB             256 15/07/23 10:15:31 *MBR       MYFILE(MBR1)",
+			Entry{Name: "MYFILE(MBR1)", Size: 256, Time: newTime(2023, time.July, 15, 10, 15, 31), Type: EntryTypeFile, IBMObjectType: "*MBR"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.line, func(t *testing.T) {
+			entry, err := parseQSYSListLine(test.line, now, time.UTC)
+			if assert.NoError(t, err) {
+				assert.Equal(t, test.expected.Name, entry.Name)
+				assert.Equal(t, test.expected.Size, entry.Size)
+				assert.True(t, test.expected.Time.Equal(entry.Time))
+				assert.Equal(t, test.expected.Type, entry.Type)
+				assert.Equal(t, test.expected.IBMObjectType, entry.IBMObjectType)
+			}
+		})
+	}
+}
+
+func TestParseQSYSListLineViaParseListLine(t *testing.T) {
+	// A *LIB line must come back as a folder even through the full
+	// parseListLine dispatch, not just when parseQSYSListLine is called
+	// directly: parseIbmListLine matches the same columns, so listLineParsers
+	// must try the QSYS parser first.
+	line := "QGPL             8192 07/15/23 10:15:30 *LIB       QGPL"
+	entry, err := parseListLine(line, newTime(2023, time.January, 1), time.UTC)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "QGPL", entry.Name)
+		assert.Equal(t, EntryTypeFolder, entry.Type)
+		assert.Equal(t, "*LIB", entry.IBMObjectType)
+	}
+}
+
+func TestParseRFC3659Facts(t *testing.T) {
+	line := "modify=20150813175250;create=20150101000000;size=951;type=file;perm=adfr;UNIX.mode=0644;UNIX.owner=1000; welcome.msg"
+	entry, err := parseListLine(line, now, time.UTC)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, "951", entry.Facts["size"])
+	assert.Equal(t, "0644", entry.Facts["unix.mode"])
+	assert.Equal(t, entry.Time, entry.ModifyTime())
+
+	created, ok := entry.CreateTime()
+	if assert.True(t, ok) {
+		assert.Equal(t, newTime(2015, time.January, 1), created)
+	}
+
+	mode, ok := entry.UnixMode()
+	if assert.True(t, ok) {
+		assert.Equal(t, os.FileMode(0644), mode)
+	}
+
+	perm, ok := entry.Perm()
+	if assert.True(t, ok) {
+		assert.True(t, perm.Has(PermRetrieve))
+		assert.True(t, perm.Has(PermAppend))
+		assert.False(t, perm.Has(PermStore))
+	}
+}
+
+func TestParseRFC3659CreateTimeMatchesModifyTimeLocation(t *testing.T) {
+	// create and modify must land in the same location; CreateTime used to
+	// hard-code UTC regardless of the loc ModifyTime was parsed in.
+	loc := time.FixedZone("UTC-5", -5*60*60)
+	line := "modify=20150813175250;create=20150101000000;type=file;size=1; welcome.msg"
+	entry, err := parseListLine(line, now, loc)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	created, ok := entry.CreateTime()
+	if assert.True(t, ok) {
+		assert.Equal(t, loc, created.Location())
+		assert.Equal(t, entry.ModifyTime().Location(), created.Location())
+	}
+}
+
+func TestParseRFC3659Symlink(t *testing.T) {
+	line := "modify=20150813175250;type=OS.unix=slink:usr/bin;UNIX.mode=0777; bin"
+	entry, err := parseListLine(line, now, time.UTC)
+	if assert.NoError(t, err) {
+		assert.Equal(t, EntryTypeLink, entry.Type)
+		assert.Equal(t, "usr/bin", entry.Target)
+	}
+}
+
+func TestParseRFC3659BadSeparator(t *testing.T) {
+	// Missing the mandatory "; " before the name.
+	line := "modify=20150813175250;type=file;size=1 welcome.msg"
+	_, err := parseListLine(line, now, time.UTC)
+	assert.Equal(t, errUnsupportedListLine, err)
+}
+
+func TestParseFlexibleDate(t *testing.T) {
+	// A reference time close enough to the 2025 fixtures below that none of
+	// them trip the "more than six months in the future" rollback.
+	refNow := newTime(2025, time.June, 15, 12, 0, 0)
+
+	tests := []struct {
+		str      string
+		expected time.Time
+	}{
+		{"2015-08-13T22:48:45Z", newTime(2015, time.August, 13, 22, 48, 45)},
+		{"13-May-2025 13:26", newTime(2025, time.May, 13, 13, 26)},
+		{"13/05/25 13:26:11", newTime(2025, time.May, 13, 13, 26, 11)}, // DD/MM/YY
+		{"2015-08-13 22:48:45", newTime(2015, time.August, 13, 22, 48, 45)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.str, func(t *testing.T) {
+			got, err := parseFlexibleDate(test.str, refNow, time.UTC)
+			if assert.NoError(t, err) {
+				assert.Equal(t, test.expected, got)
+			}
+		})
+	}
+
+	_, err := parseFlexibleDate("not a date", refNow, time.UTC)
+	assert.Equal(t, errUnsupportedListDate, err)
+}
+
+func TestParseFlexibleDateKeepsExplicitFutureYear(t *testing.T) {
+	// A fully-qualified future timestamp must not be rolled back a year just
+	// because it's more than six months ahead of now: the year came
+	// straight from the string, there's no ambiguity to resolve.
+	got, err := parseFlexibleDate("2030-01-02 03:04:05", now, time.UTC)
+	if assert.NoError(t, err) {
+		assert.Equal(t, newTime(2030, time.January, 2, 3, 4, 5), got)
+	}
+}
+
+func TestRegisterDateLayoutYearless(t *testing.T) {
+	const layout = "Jan _2 15:04:05"
+	RegisterDateLayout(layout)
+
+	refNow := newTime(2025, time.June, 15, 12, 0, 0)
+
+	// Within six months of now: taken at face value in now's year.
+	got, err := parseFlexibleDate("Mar  1 10:00:00", refNow, time.UTC)
+	if assert.NoError(t, err) {
+		assert.Equal(t, newTime(2025, time.March, 1, 10, 0, 0), got)
+	}
+
+	// More than six months ahead of now: assumed to be last year's, the
+	// same ambiguity setTime resolves for yearless `ls` dates.
+	got, err = parseFlexibleDate("Dec 25 10:00:00", refNow, time.UTC)
+	if assert.NoError(t, err) {
+		assert.Equal(t, newTime(2024, time.December, 25, 10, 0, 0), got)
+	}
+}
+
+func TestRegisterDateLayout(t *testing.T) {
+	const layout = "2006/01/02"
+	RegisterDateLayout(layout)
+
+	got, err := parseFlexibleDate("2015/08/13", now, time.UTC)
+	if assert.NoError(t, err) {
+		assert.Equal(t, newTime(2015, time.August, 13), got)
+	}
+}
+
+func TestRegisterListParser(t *testing.T) {
+	const name = "novell-test"
+	line := "d [R----F--] supervisor            512       Jan 16 18:53 login"
+
+	// Unregistered custom format is unsupported.
+	_, err := parseListLine(line, now, time.UTC)
+	assert.Equal(t, errUnsupportedListLine, err)
+
+	RegisterListParser(name, func(line string, now time.Time, loc *time.Location) (*Entry, error) {
+		if !strings.HasPrefix(line, "d [") {
+			return nil, errUnsupportedListLine
+		}
+		return &Entry{Name: "login", Type: EntryTypeFolder}, nil
+	})
+	defer UnregisterListParser(name)
+
+	parsers := ListParsers()
+	assert.Equal(t, len(listLineParsers)+1, len(parsers))
+
+	entry, err := parseListLine(line, now, time.UTC)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "login", entry.Name)
+		assert.Equal(t, EntryTypeFolder, entry.Type)
+	}
+
+	UnregisterListParser(name)
+	_, err = parseListLine(line, now, time.UTC)
+	assert.Equal(t, errUnsupportedListLine, err)
+}
+
 // newTime builds a UTC time from the given year, month, day, hour and minute
 func newTime(year int, month time.Month, day int, hourMinSec ...int) time.Time {
 	var hour, min, sec int
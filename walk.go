@@ -0,0 +1,295 @@
+package ftp
+
+import (
+	"path"
+	"sync"
+	"time"
+)
+
+// WalkOption configures WalkRange.
+type WalkOption struct {
+	setup func(wo *walkOptions)
+}
+
+type walkOptions struct {
+	followSymlinks bool
+	typeFilter     EntryType
+	hasTypeFilter  bool
+	stop           <-chan struct{}
+	concurrency    int
+	dial           func() (*ServerConn, error)
+}
+
+// WithFollowSymlinks makes WalkRange descend into directories reached
+// through a symlink entry. The default is false.
+func WithFollowSymlinks(follow bool) WalkOption {
+	return WalkOption{func(wo *walkOptions) {
+		wo.followSymlinks = follow
+	}}
+}
+
+// WithFileTypeFilter restricts the entries WalkRange yields to et.
+// Directories are still traversed regardless of this filter; it only
+// affects what gets sent on the entries channel.
+func WithFileTypeFilter(et EntryType) WalkOption {
+	return WalkOption{func(wo *walkOptions) {
+		wo.typeFilter = et
+		wo.hasTypeFilter = true
+	}}
+}
+
+// WithConcurrency lets WalkRange read up to n directories at once, instead
+// of reading one directory at a time over c. A single ServerConn only ever
+// has one command/data exchange outstanding at a time, so real fan-out
+// needs distinct connections: WalkRange calls dial concurrency-1 times up
+// front to build a small pool alongside c (dial should return a connection
+// logged in and rooted the same way as c), and every read checks a
+// connection out of that pool for the duration of its List call, so no
+// connection ever runs two List calls at once. WalkRange closes every
+// connection it obtained from dial once the walk completes or is
+// cancelled. n <= 0 or a nil dial is ignored, i.e. WalkRange falls back to
+// reading one directory at a time over c alone.
+func WithConcurrency(n int, dial func() (*ServerConn, error)) WalkOption {
+	return WalkOption{func(wo *walkOptions) {
+		if n > 0 && dial != nil {
+			wo.concurrency = n
+			wo.dial = dial
+		}
+	}}
+}
+
+// resolveLinkTarget turns the target of a symlink entry found in dir into
+// the canonical path it points at, so repeated visits to the same real
+// location (e.g. through a self- or mutually-referential symlink) can be
+// recognized even though the walk path leading to it keeps growing.
+func resolveLinkTarget(dir, target string) string {
+	if target == "" {
+		return path.Clean(dir)
+	}
+	if path.IsAbs(target) {
+		return path.Clean(target)
+	}
+	return path.Clean(path.Join(dir, target))
+}
+
+// WithCancel lets the caller stop an in-progress WalkRange early: closing
+// stop (or sending on it) makes WalkRange abandon any directory reads still
+// in flight and drain down to a closed entries channel, instead of the
+// caller having to read every entry to reach that point. The only clean way
+// to abort a read that's already blocked on the network is to close the
+// connection out from under it, so cancelling a walk leaves c, and any
+// connection WithConcurrency dialed, unusable; dial fresh connections if you
+// need to keep talking to the server afterward.
+func WithCancel(stop <-chan struct{}) WalkOption {
+	return WalkOption{func(wo *walkOptions) {
+		wo.stop = stop
+	}}
+}
+
+// WalkRange recursively lists root and yields, on the returned channel,
+// every entry whose Time falls within [start, end), except directories:
+// those are descended into but never yielded themselves. Each directory is
+// listed with List, which already prefers MLSD and falls back to LIST+the
+// registered parsers, so WalkRange benefits from both transparently.
+//
+// Archival/mirroring tools frequently want "everything modified between T1
+// and T2" without buffering whole directories into memory first; WalkRange
+// streams results as they're found instead of requiring callers to collect
+// and post-filter a List/NameList result.
+//
+// Directories are read one at a time over c unless WithConcurrency names a
+// dial func, in which case reads fan out across c and the connections
+// WalkRange dials, never more than one List call per connection at a time.
+//
+// Both channels are closed once the walk completes. Pass WithCancel to stop
+// a walk early and have its in-flight directory reads aborted promptly
+// instead of leaking until the walk would have finished on its own. A
+// single error from any directory read stops the rest of the walk, rather
+// than risk silently skipping a subtree while reporting success.
+func (c *ServerConn) WalkRange(root string, start, end time.Time, opts ...WalkOption) (<-chan Entry, <-chan error) {
+	wo := walkOptions{concurrency: 1}
+	for _, opt := range opts {
+		opt.setup(&wo)
+	}
+
+	entries := make(chan Entry)
+	errs := make(chan error, wo.concurrency+1)
+
+	abort := make(chan struct{})
+	var abortOnce sync.Once
+	sendErr := func(err error) {
+		select {
+		case errs <- err:
+		default:
+		}
+		abortOnce.Do(func() { close(abort) })
+	}
+
+	stopped := func() bool {
+		select {
+		case <-wo.stop:
+			return true
+		case <-abort:
+			return true
+		default:
+			return false
+		}
+	}
+
+	// pool holds the connections WalkRange reads directories over: c plus,
+	// if WithConcurrency named a dial func, up to concurrency-1 more. list
+	// checks a connection out of the pool for the duration of its List
+	// call and returns it once that call actually completes, so at most
+	// one List runs per connection at a time.
+	pool := make(chan *ServerConn, wo.concurrency)
+	pool <- c
+	var dialed []*ServerConn
+	for i := 1; i < wo.concurrency; i++ {
+		conn, err := wo.dial()
+		if err != nil {
+			sendErr(err)
+			break
+		}
+		dialed = append(dialed, conn)
+		pool <- conn
+	}
+
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-wo.stop:
+		case <-abort:
+		case <-done:
+			for _, conn := range dialed {
+				conn.Quit()
+			}
+			return
+		}
+		c.Quit()
+		for _, conn := range dialed {
+			conn.Quit()
+		}
+	}()
+
+	// list runs conn.List(dir) on its own goroutine so that a cancel or
+	// abort arriving while the read is still blocked on the network can
+	// act on it immediately instead of waiting for it to finish on its
+	// own; the connection is only returned to the pool once its List call
+	// actually completes, never when it's abandoned early, so a
+	// since-closed connection can't be handed to a second concurrent
+	// caller.
+	type listResult struct {
+		entries []*Entry
+		err     error
+	}
+	list := func(dir string) ([]*Entry, error) {
+		var conn *ServerConn
+		select {
+		case conn = <-pool:
+		case <-wo.stop:
+			return nil, nil
+		case <-abort:
+			return nil, nil
+		}
+
+		resultCh := make(chan listResult, 1)
+		go func() {
+			children, err := conn.List(dir)
+			resultCh <- listResult{children, err}
+		}()
+
+		select {
+		case r := <-resultCh:
+			pool <- conn
+			return r.entries, r.err
+		case <-wo.stop:
+			return nil, nil
+		case <-abort:
+			return nil, nil
+		}
+	}
+
+	// visitedLinks records the canonical target of every symlink followed
+	// so far, so a self- or mutually-referential symlink is only descended
+	// into once instead of sending walkDir into unbounded recursion.
+	var visitedLinksMu sync.Mutex
+	visitedLinks := map[string]bool{}
+
+	var wg sync.WaitGroup
+	var walkDir func(dir string)
+	walkDir = func(dir string) {
+		defer wg.Done()
+		if stopped() {
+			return
+		}
+
+		children, err := list(dir)
+		if stopped() {
+			return
+		}
+		if err != nil {
+			// A single failed read stops the whole walk rather than risk
+			// silently skipping this subtree while the walk otherwise
+			// reports success.
+			sendErr(err)
+			return
+		}
+
+		for _, e := range children {
+			if e.Name == "." || e.Name == ".." {
+				continue
+			}
+			if stopped() {
+				return
+			}
+			childPath := path.Join(dir, e.Name)
+
+			descend := e.Type == EntryTypeFolder
+			if e.Type == EntryTypeLink && wo.followSymlinks {
+				real := resolveLinkTarget(dir, e.Target)
+				visitedLinksMu.Lock()
+				if !visitedLinks[real] {
+					visitedLinks[real] = true
+					descend = true
+				}
+				visitedLinksMu.Unlock()
+			}
+			if descend {
+				wg.Add(1)
+				go walkDir(childPath)
+			}
+
+			if e.Type == EntryTypeFolder {
+				continue
+			}
+			if wo.hasTypeFilter && e.Type != wo.typeFilter {
+				continue
+			}
+			if e.Time.Before(start) || !e.Time.Before(end) {
+				continue
+			}
+
+			entry := *e
+			entry.Name = childPath
+			select {
+			case entries <- entry:
+			case <-wo.stop:
+				return
+			case <-abort:
+				return
+			}
+		}
+	}
+
+	wg.Add(1)
+	go walkDir(root)
+
+	go func() {
+		wg.Wait()
+		close(done)
+		close(entries)
+		close(errs)
+	}()
+
+	return entries, errs
+}
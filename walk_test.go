@@ -0,0 +1,42 @@
+package ftp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithConcurrency(t *testing.T) {
+	dial := func() (*ServerConn, error) { return nil, nil }
+
+	var wo walkOptions
+	WithConcurrency(4, dial).setup(&wo)
+	assert.Equal(t, 4, wo.concurrency)
+	assert.NotNil(t, wo.dial)
+
+	// n <= 0 or a nil dial leaves the default (serial) behavior alone.
+	wo = walkOptions{}
+	WithConcurrency(0, dial).setup(&wo)
+	assert.Equal(t, 0, wo.concurrency)
+	assert.Nil(t, wo.dial)
+
+	wo = walkOptions{}
+	WithConcurrency(4, nil).setup(&wo)
+	assert.Equal(t, 0, wo.concurrency)
+	assert.Nil(t, wo.dial)
+}
+
+func TestResolveLinkTarget(t *testing.T) {
+	tests := []struct {
+		dir, target, expected string
+	}{
+		{"/home/user", "docs", "/home/user/docs"},
+		{"/home/user", "/var/log", "/var/log"},
+		{"/home/user/self", "..", "/home/user"},
+		{"/home/user", "", "/home/user"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, resolveLinkTarget(test.dir, test.target))
+	}
+}